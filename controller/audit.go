@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+)
+
+// Outcome values recorded in an AuditRecord.
+const (
+	AuditOutcomeRescheduled     = "Rescheduled"
+	AuditOutcomeNoAlternateArch = "NoAlternateArch"
+	AuditOutcomePatchFailed     = "PatchFailed"
+)
+
+// ownerRefEntry is one link in the owner reference chain
+// getOriginControllerObject climbs, from the pod itself up to the object the
+// controller ultimately targets.
+type ownerRefEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace"`
+	Name       string `json:"name"`
+}
+
+// AuditRecord documents a single rescheduling decision, whether or not it
+// resulted in a patch, so operators can reconstruct every mutation the
+// controller has made - or declined to make - from an audit log.
+type AuditRecord struct {
+	Time         time.Time       `json:"time"`
+	PodUID       string          `json:"podUID"`
+	PodNamespace string          `json:"podNamespace"`
+	PodName      string          `json:"podName"`
+	OwnerChain   []ownerRefEntry `json:"ownerChain"`
+	DetectedArch string          `json:"detectedArch"`
+	PatchBody    json.RawMessage `json:"patchBody,omitempty"`
+	Outcome      string          `json:"outcome"`
+	Message      string          `json:"message,omitempty"`
+}
+
+// AuditSink records AuditRecords somewhere an operator can review them.
+type AuditSink interface {
+	Record(record AuditRecord) error
+}
+
+// jsonAuditSink writes one JSON object per line to w. It is safe for
+// concurrent use by the controller's worker goroutines.
+type jsonAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink creates an AuditSink that appends newline-delimited JSON
+// records to w, e.g. os.Stdout or an opened audit log file.
+func NewJSONAuditSink(w io.Writer) AuditSink {
+	return &jsonAuditSink{enc: json.NewEncoder(w)}
+}
+
+func (s *jsonAuditSink) Record(record AuditRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.enc.Encode(record); err != nil {
+		return xerrors.Errorf("failed to write audit record: %w", err)
+	}
+	return nil
+}
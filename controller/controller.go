@@ -1,80 +1,119 @@
 package controller
 
 import (
-	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
-	"io"
+	"sync"
+	"time"
 
 	"golang.org/x/xerrors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
-	"k8s.io/gengo/namer"
-	gengotypes "k8s.io/gengo/types"
 	"k8s.io/klog"
-)
 
-const (
-	errorMessage = "standard_init_linux.go:211: exec user process caused \"exec format error\"\n"
-	patchJSON    = `
-{
-  "spec": {
-    "template": {
-      "spec": {
-        "affinity": {
-          "nodeAffinity": {
-            "requiredDuringSchedulingIgnoredDuringExecution": {
-              "nodeSelectorTerms": [
-                {
-                  "matchExpressions": [
-                    {
-                      "key": "beta.kubernetes.io/arch",
-                      "operator": "NotIn",
-                      "values": [
-                        "%s"
-                      ]
-                    }
-                  ]
-                }
-              ]
-            }
-          }
-        }
-      }
-    }
-  }
-}
-`
+	schedulingv1alpha1 "github.com/hnts/arch-scheduling-controller/pkg/apis/scheduling/v1alpha1"
+	policyinformers "github.com/hnts/arch-scheduling-controller/pkg/generated/informers/externalversions"
+	policylisters "github.com/hnts/arch-scheduling-controller/pkg/generated/listers/scheduling/v1alpha1"
 )
 
 // ArchSchedulingController reschedule pods that failed to run with exec format error
 // to another architecture node
 type ArchSchedulingController struct {
-	informerFactory informers.SharedInformerFactory
-	podInformer     coreinformers.PodInformer
-	podListener     listers.PodLister
-	kubeClientset   kubernetes.Interface
-	workqueue       workqueue.RateLimitingInterface
+	informerFactory       informers.SharedInformerFactory
+	podInformer           coreinformers.PodInformer
+	podListener           listers.PodLister
+	nodeInformer          coreinformers.NodeInformer
+	policyInformerFactory policyinformers.SharedInformerFactory
+	policyInformer        cache.SharedIndexInformer
+	policyLister          policylisters.ArchSchedulingPolicyLister
+	kubeClientset         kubernetes.Interface
+	dynamicClient         dynamic.Interface
+	restMapper            meta.RESTMapper
+	workqueue             workqueue.RateLimitingInterface
+	detectors             []Detector
+	cooldown              *patchCooldown
+	nodeArchIndex         *NodeArchIndex
+	inspectImageManifests bool
+	eventBroadcaster      record.EventBroadcaster
+	eventRecorder         record.EventRecorder
+	metrics               *Metrics
+	auditSink             AuditSink
 }
 
-// NewArchSchedulingController creates a ArchSchedulingController
-func NewArchSchedulingController(informerFactory informers.SharedInformerFactory, kubeClientset kubernetes.Interface) *ArchSchedulingController {
+// NewArchSchedulingController creates a ArchSchedulingController.
+// logMatchPattern is the regular expression used by the log-scraping
+// fallback detector when none of the structured detectors (container
+// termination state, correlated events) find a match. policyInformerFactory
+// drives the ArchSchedulingPolicy CRs that configure, per matching workload,
+// how the controller reschedules a pod. dynamicClient and restMapper let the
+// controller resolve and patch owning controller objects of any API group
+// (Deployments, Jobs, CronJobs, DaemonSets, StatefulSets, or CRD-managed
+// controllers), not just the built-in apps/v1 kinds. When
+// inspectImageManifests is set, the controller additionally fetches each
+// container's OCI manifest to narrow a rescheduling decision down to
+// architectures the image itself supports, not just the ones present in the
+// cluster. metrics is registered as the global workqueue.MetricsProvider, so
+// it must be a fresh *Metrics the caller hasn't handed to another workqueue.
+// auditSink may be nil, in which case no audit records are written.
+func NewArchSchedulingController(informerFactory informers.SharedInformerFactory, policyInformerFactory policyinformers.SharedInformerFactory, kubeClientset kubernetes.Interface, dynamicClient dynamic.Interface, restMapper meta.RESTMapper, logMatchPattern string, inspectImageManifests bool, metrics *Metrics, auditSink AuditSink) (*ArchSchedulingController, error) {
 	podInformer := informerFactory.Core().V1().Pods()
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	policyInformer := policyInformerFactory.Scheduling().V1alpha1().ArchSchedulingPolicies()
+
+	workqueue.SetProvider(metrics)
+
+	logDetector, err := newLogRegexDetector(kubeClientset, logMatchPattern, metrics.ObserveLogFetchDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(klog.Infof)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClientset.CoreV1().Events("")})
+	eventRecorder := eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "arch-scheduling-controller"})
+
 	c := &ArchSchedulingController{
-		informerFactory: informerFactory,
-		podInformer:     podInformer,
-		podListener:     podInformer.Lister(),
-		kubeClientset:   kubeClientset,
-		workqueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Pods"),
+		informerFactory:       informerFactory,
+		podInformer:           podInformer,
+		podListener:           podInformer.Lister(),
+		nodeInformer:          nodeInformer,
+		policyInformerFactory: policyInformerFactory,
+		policyInformer:        policyInformer.Informer(),
+		policyLister:          policyInformer.Lister(),
+		kubeClientset:         kubeClientset,
+		dynamicClient:         dynamicClient,
+		restMapper:            restMapper,
+		workqueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "Pods"),
+		detectors: []Detector{
+			terminationStateDetector{},
+			eventDetector{kubeClientset: kubeClientset},
+			logDetector,
+		},
+		cooldown:              newPatchCooldown(),
+		nodeArchIndex:         NewNodeArchIndex(nodeInformer.Lister()),
+		inspectImageManifests: inspectImageManifests,
+		eventBroadcaster:      eventBroadcaster,
+		eventRecorder:         eventRecorder,
+		metrics:               metrics,
+		auditSink:             auditSink,
 	}
 
 	podInformer.Informer().AddEventHandler(
@@ -90,29 +129,66 @@ func NewArchSchedulingController(informerFactory informers.SharedInformerFactory
 			},
 		},
 	)
-	return c
+	return c, nil
 }
 
-// Run sets up the shared informers and waits for synchronizing
-// the shared informer cache.
-func (c *ArchSchedulingController) Run(stopCh chan struct{}) error {
+// Run sets up the shared informers, waits for them to sync, then starts
+// workers workqueue-draining goroutines. It blocks until stopCh is closed,
+// at which point it waits for the workers to finish their current item
+// before returning. Callers running under leader election should tie stopCh
+// to the leading context so Run exits cleanly on handover.
+func (c *ArchSchedulingController) Run(stopCh <-chan struct{}, workers int) error {
 	defer runtime.HandleCrash()
-	defer c.workqueue.ShutDown()
+	defer c.eventBroadcaster.Shutdown()
 
 	c.informerFactory.Start(stopCh)
+	c.policyInformerFactory.Start(stopCh)
 	klog.Info("Starting Arch scheduling controller")
 
 	klog.Info("Waiting for informer caches to sync")
-	if !cache.WaitForCacheSync(stopCh, c.podInformer.Informer().HasSynced) {
+	if !cache.WaitForCacheSync(stopCh, c.podInformer.Informer().HasSynced, c.nodeInformer.Informer().HasSynced, c.policyInformer.HasSynced) {
 		return xerrors.New("Failed to wait for sync to cache")
 	}
 
-	for c.processEnqueuedItem() {
+	klog.Infof("Starting %d workers", workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wait.Until(c.runWorker, time.Second, stopCh)
+		}()
 	}
 
+	<-stopCh
+	klog.Info("Shutting down, waiting for workers to finish")
+	// ShutDown must happen before wg.Wait(): wait.Until does not interrupt an
+	// in-flight call to runWorker when stopCh closes, so a worker parked in
+	// workqueue.Get() would otherwise never return and wg.Wait() would block
+	// forever. ShutDown() makes Get() return immediately.
+	c.workqueue.ShutDown()
+	wg.Wait()
+
 	return nil
 }
 
+// HasSynced reports whether the controller's informer caches have completed
+// their initial sync, so a readiness probe can gate on it.
+func (c *ArchSchedulingController) HasSynced() bool {
+	return c.podInformer.Informer().HasSynced() && c.nodeInformer.Informer().HasSynced() && c.policyInformer.HasSynced()
+}
+
+// Metrics returns the controller's Prometheus metrics collector, so callers
+// can mount its Handler at /metrics.
+func (c *ArchSchedulingController) Metrics() *Metrics {
+	return c.metrics
+}
+
+func (c *ArchSchedulingController) runWorker() {
+	for c.processEnqueuedItem() {
+	}
+}
+
 func (c *ArchSchedulingController) processEnqueuedItem() bool {
 	obj, shutdown := c.workqueue.Get()
 	if shutdown {
@@ -165,59 +241,210 @@ func (c *ArchSchedulingController) reSchedulePod(key string) error {
 		return err
 	}
 
-	cobj, err := c.getOriginControllerObject(pod, pod.Kind)
+	cobj, ownerChain, err := c.getOriginControllerObject(context.TODO(), pod, podGVK, podGVR)
+	if err != nil {
+		return err
+	}
+
+	policy, err := c.selectPolicy(pod)
 	if err != nil {
 		return err
 	}
+	spec := defaultPolicySpec
+	if policy != nil {
+		spec = resolvePolicySpec(policy.Spec)
+	}
+
+	if !c.cooldown.elapsed(*cobj, spec.Cooldown.Duration) {
+		klog.V(4).Infof("Skipping patch for %s %s/%s: cooldown has not elapsed", cobj.gvk.Kind, cobj.namespace, cobj.name)
+		return nil
+	}
 
 	node, err := c.kubeClientset.CoreV1().Nodes().Get(context.TODO(), pod.Spec.NodeName, metav1.GetOptions{})
 	if err != nil {
 		return err
 	}
+	failedArch := node.GetLabels()[spec.ArchLabelKey]
+
+	candidates, err := c.nodeArchIndex.AvailableArches(spec.ArchLabelKey, pod.Spec.NodeSelector, pod.Spec.Tolerations)
+	if err != nil {
+		return err
+	}
+	delete(candidates, failedArch)
+
+	if c.inspectImageManifests {
+		if narrowed, ok, ierr := c.intersectImageArches(context.TODO(), pod, candidates); ierr != nil {
+			klog.Warningf("Failed to inspect image manifests for pod %s/%s, continuing without image-based filtering: %s", pod.Namespace, pod.Name, ierr)
+		} else if ok {
+			candidates = narrowed
+		}
+	}
+
+	if len(candidates) == 0 {
+		message := fmt.Sprintf("pod %s/%s failed on architecture %q and no alternate-architecture node is available; not patching", pod.Namespace, pod.Name, failedArch)
+		klog.Warningf("Refusing to patch %s %s/%s: %s", cobj.gvk.Kind, cobj.namespace, cobj.name, message)
+		c.metrics.ObservePatch(cobj.gvk.Kind, AuditOutcomeNoAlternateArch)
+		c.recordArchDecision(context.TODO(), *cobj, v1.EventTypeWarning, "RescheduleFailed", message)
+		c.recordAudit(pod, ownerChain, failedArch, nil, AuditOutcomeNoAlternateArch, message)
+		return nil
+	}
 
-	patchString := fmt.Sprintf(patchJSON, node.GetLabels()["beta.kubernetes.io/arch"])
-	err = c.patchToControllerObject(*cobj, []byte(patchString))
+	operator := v1.NodeSelectorOpNotIn
+	archValue := failedArch
+	if spec.Strategy == schedulingv1alpha1.StrategyPreferDiscoveredArch {
+		if discovered, ok := pickArch(candidates); ok {
+			operator = v1.NodeSelectorOpIn
+			archValue = discovered
+		} else {
+			klog.Warningf("No alternate architecture discovered for %s %s/%s, falling back to excluding %q", cobj.gvk.Kind, cobj.namespace, cobj.name, failedArch)
+		}
+	}
+
+	patch, err := buildAffinityPatch(spec, operator, archValue)
 	if err != nil {
 		return err
 	}
 
+	if err := c.patchToControllerObject(context.TODO(), *cobj, patch); err != nil {
+		message := fmt.Sprintf("failed to patch node affinity away from architecture %q: %s", failedArch, err)
+		c.metrics.ObservePatch(cobj.gvk.Kind, AuditOutcomePatchFailed)
+		c.recordArchDecision(context.TODO(), *cobj, v1.EventTypeWarning, "RescheduleFailed", message)
+		c.recordAudit(pod, ownerChain, failedArch, patch, AuditOutcomePatchFailed, message)
+		return err
+	}
+	c.cooldown.record(*cobj)
+	message := fmt.Sprintf("patched node affinity (%s %s) after pod %s/%s failed on architecture %q", operator, archValue, pod.Namespace, pod.Name, failedArch)
+	c.metrics.ObservePatch(cobj.gvk.Kind, AuditOutcomeRescheduled)
+	c.recordArchDecision(context.TODO(), *cobj, v1.EventTypeNormal, "RescheduledArch", message)
+	c.recordAudit(pod, ownerChain, failedArch, patch, AuditOutcomeRescheduled, message)
+
 	return nil
 }
 
+// recordAudit writes an AuditRecord for a rescheduling decision if an audit
+// sink is configured; it is a no-op otherwise.
+func (c *ArchSchedulingController) recordAudit(pod *v1.Pod, ownerChain []ownerRefEntry, detectedArch string, patch []byte, outcome, message string) {
+	if c.auditSink == nil {
+		return
+	}
+
+	record := AuditRecord{
+		Time:         time.Now(),
+		PodUID:       string(pod.UID),
+		PodNamespace: pod.Namespace,
+		PodName:      pod.Name,
+		OwnerChain:   ownerChain,
+		DetectedArch: detectedArch,
+		Outcome:      outcome,
+		Message:      message,
+	}
+	if patch != nil {
+		record.PatchBody = json.RawMessage(patch)
+	}
+
+	if err := c.auditSink.Record(record); err != nil {
+		klog.Errorf("Failed to write audit record for pod %s/%s: %s", pod.Namespace, pod.Name, err)
+	}
+}
+
+// recordArchDecision posts an Event on the owning controller object
+// documenting a rescheduling decision. Fetching the object is a best-effort
+// extra call made only on the (comparatively rare) decision path, not on
+// every reconcile.
+func (c *ArchSchedulingController) recordArchDecision(ctx context.Context, cobj controllerObject, eventType, reason, message string) {
+	owner, err := c.getControllerObjectUnstructured(ctx, cobj)
+	if err != nil {
+		klog.Errorf("Failed to fetch %s %s/%s to record event: %s", cobj.gvk.Kind, cobj.namespace, cobj.name, err)
+		return
+	}
+	c.eventRecorder.Event(owner, eventType, reason, message)
+}
+
+// getControllerObjectUnstructured fetches the current state of the object
+// cobj identifies, for use as the Event's involved object.
+func (c *ArchSchedulingController) getControllerObjectUnstructured(ctx context.Context, cobj controllerObject) (*unstructured.Unstructured, error) {
+	resourceClient, _, err := c.resourceInterfaceFor(cobj.gvk, cobj.namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, err := resourceClient.Get(ctx, cobj.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to get %s %s/%s: %w", cobj.gvk.Kind, cobj.namespace, cobj.name, err)
+	}
+	return owner, nil
+}
+
 func (c *ArchSchedulingController) handleObject(obj interface{}) {
 	pod := obj.(*v1.Pod)
+
+	shouldDetect := false
 	for _, cs := range pod.Status.ContainerStatuses {
 		if cs.State.Waiting != nil && cs.State.Waiting.Reason == "CrashLoopBackOff" {
-			for _, ct := range pod.Spec.Containers {
-				req := c.kubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: ct.Name})
-				body, err := req.Stream(context.Background())
-				if err != nil {
-					klog.Errorf("Failed to get logs: %s", err)
-					continue
-				}
+			shouldDetect = true
+			break
+		}
+	}
+	if !shouldDetect && pod.Spec.NodeName != "" && len(pod.Status.ContainerStatuses) == 0 {
+		// The pod has been scheduled but no container status has been
+		// recorded yet, e.g. because its sandbox failed to create. Such a
+		// pod never reaches CrashLoopBackOff, so fall through to the
+		// detectors anyway - this is exactly the case eventDetector exists
+		// to catch via the Failed/FailedCreatePodSandBox events the runtime
+		// emits before a container status exists.
+		shouldDetect = true
+	}
+	if !shouldDetect {
+		return
+	}
 
-				var buf bytes.Buffer
-				n, err := io.Copy(&buf, body)
-				if err != nil {
-					klog.Errorf("Failed to read logs: %s", err)
-					continue
-				}
-				if n == 0 {
-					klog.V(4).Infoln("Empty log")
-				}
+	for _, d := range c.detectors {
+		res, err := d.Detect(context.Background(), pod)
+		if err != nil {
+			klog.Errorf("Detector %q failed for pod %s/%s: %s", d.Name(), pod.Namespace, pod.Name, err)
+			continue
+		}
+		if res != nil {
+			klog.Infof("Detector %q matched pod %s/%s: %s (%s)", d.Name(), pod.Namespace, pod.Name, res.Reason, res.Detail)
+			go c.recordDetection(pod, res)
+			c.enqueuePod(pod)
+			return
+		}
+	}
+}
 
-				err = body.Close()
-				if err != nil {
-					klog.Errorf("Failed to close response body: %s", err)
-				}
+// recordDetection increments the detection counter and posts an
+// ArchMismatchDetected event on the pod's owning controller object. It is
+// called in its own goroutine by handleObject so its Node and owner-chain
+// lookups never delay enqueuing the pod or block the shared informer's
+// event handler. Failures resolving the node's architecture or the owner
+// chain only degrade observability, so they're logged rather than
+// propagated - the pod is enqueued for rescheduling regardless.
+func (c *ArchSchedulingController) recordDetection(pod *v1.Pod, res *DetectionResult) {
+	archLabelKey := defaultArchLabelKey
+	if policy, err := c.selectPolicy(pod); err != nil {
+		klog.Errorf("Failed to select policy for pod %s/%s while recording detection: %s", pod.Namespace, pod.Name, err)
+	} else if policy != nil {
+		archLabelKey = resolvePolicySpec(policy.Spec).ArchLabelKey
+	}
 
-				if buf.String() == errorMessage {
-					c.enqueuePod(pod)
-					return
-				}
-			}
+	arch := ""
+	if pod.Spec.NodeName != "" {
+		if node, err := c.kubeClientset.CoreV1().Nodes().Get(context.TODO(), pod.Spec.NodeName, metav1.GetOptions{}); err != nil {
+			klog.Errorf("Failed to get node %q while recording detection for pod %s/%s: %s", pod.Spec.NodeName, pod.Namespace, pod.Name, err)
+		} else {
+			arch = node.GetLabels()[archLabelKey]
 		}
 	}
+	c.metrics.ObserveDetection(arch, res.Reason)
+
+	cobj, _, err := c.getOriginControllerObject(context.TODO(), pod, podGVK, podGVR)
+	if err != nil {
+		klog.Errorf("Failed to resolve owning controller object for pod %s/%s while recording detection: %s", pod.Namespace, pod.Name, err)
+		return
+	}
+	c.recordArchDecision(context.TODO(), *cobj, v1.EventTypeWarning, "ArchMismatchDetected",
+		fmt.Sprintf("pod %s/%s detected as an architecture mismatch (%s): %s", pod.Namespace, pod.Name, res.Reason, res.Detail))
 }
 
 func (c *ArchSchedulingController) enqueuePod(obj interface{}) {
@@ -230,49 +457,100 @@ func (c *ArchSchedulingController) enqueuePod(obj interface{}) {
 	c.workqueue.Add(key)
 }
 
-func lowercaseAndPluralise(s string) string {
-	pluralise := namer.NewAllLowercasePluralNamer(make(map[string]string))
-	pluralType := gengotypes.Type{
-		Name: gengotypes.Name{Name: s},
-	}
-
-	return pluralise.Name(&pluralType)
-}
+// podGVK and podGVR are the statically known coordinates of the Pod kind,
+// used as the starting point for getOriginControllerObject's climb up the
+// owner reference chain.
+var (
+	podGVK = v1.SchemeGroupVersion.WithKind("Pod")
+	podGVR = v1.SchemeGroupVersion.WithResource("pods")
+)
 
+// controllerObject identifies the Kubernetes object the controller should
+// patch: the root of obj's owner reference chain, resolved to a concrete
+// GroupVersionResource so it can be addressed through the dynamic client
+// regardless of which API group it belongs to.
 type controllerObject struct {
-	kind      string
+	gvk       schema.GroupVersionKind
+	gvr       schema.GroupVersionResource
 	name      string
 	namespace string
 }
 
-func (c *ArchSchedulingController) getOriginControllerObject(obj interface{}, kind string) (*controllerObject, error) {
-	object := obj.(metav1.Object)
+// resourceInterfaceFor maps gvk to a concrete GroupVersionResource via the
+// discovery-backed RESTMapper and returns a dynamic client scoped to
+// namespace, if the resource is namespaced.
+func (c *ArchSchedulingController) resourceInterfaceFor(gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, schema.GroupVersionResource, error) {
+	mapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, schema.GroupVersionResource{}, xerrors.Errorf("failed to map %s to a resource: %w", gvk, err)
+	}
 
-	if ownerRef := metav1.GetControllerOf(object); ownerRef != nil {
-		req := c.kubeClientset.AppsV1().RESTClient().Get().Resource(lowercaseAndPluralise(ownerRef.Kind)).Namespace(object.GetNamespace()).Name(ownerRef.Name)
-		res, err := req.Do(context.TODO()).Get()
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return c.dynamicClient.Resource(mapping.Resource).Namespace(namespace), mapping.Resource, nil
+	}
+	return c.dynamicClient.Resource(mapping.Resource), mapping.Resource, nil
+}
 
-		if err != nil {
-			return nil, err
-		}
+// hasPatchableTemplate reports whether owner exposes the
+// spec.template.spec path ArchSchedulingController's node affinity patch
+// targets. Owners that don't (e.g. a CR with a differently shaped spec)
+// are skipped so the climb stops at the last object that can actually be
+// patched.
+func hasPatchableTemplate(owner *unstructured.Unstructured) bool {
+	_, found, err := unstructured.NestedMap(owner.Object, "spec", "template", "spec")
+	return err == nil && found
+}
 
-		return c.getOriginControllerObject(res, ownerRef.Kind)
+// getOriginControllerObject walks obj's "controller" owner references (Pod
+// -> ReplicaSet -> Deployment -> ..., or any other chain, including
+// CRD-managed controllers such as a Rollout) until it reaches an object with
+// no further controller owner, and returns that object's coordinates. If an
+// owner's pod template is not patch-compatible, the climb stops at the
+// current object instead of continuing into that owner. The second return
+// value is the chain of objects visited, from obj itself up to the returned
+// controllerObject, for use in audit records.
+func (c *ArchSchedulingController) getOriginControllerObject(ctx context.Context, obj metav1.Object, gvk schema.GroupVersionKind, gvr schema.GroupVersionResource) (*controllerObject, []ownerRefEntry, error) {
+	entry := ownerRefEntry{APIVersion: gvk.GroupVersion().String(), Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+
+	ownerRef := metav1.GetControllerOf(obj)
+	if ownerRef == nil {
+		return &controllerObject{gvk: gvk, gvr: gvr, name: obj.GetName(), namespace: obj.GetNamespace()}, []ownerRefEntry{entry}, nil
 	}
 
-	return &controllerObject{kind: kind, name: object.GetName(), namespace: object.GetNamespace()}, nil
-}
+	ownerGVK := schema.FromAPIVersionAndKind(ownerRef.APIVersion, ownerRef.Kind)
+	ownerClient, ownerGVR, err := c.resourceInterfaceFor(ownerGVK, obj.GetNamespace())
+	if err != nil {
+		return nil, nil, err
+	}
 
-func (c *ArchSchedulingController) patchToControllerObject(obj controllerObject, patch []byte) error {
-	req := c.kubeClientset.AppsV1().RESTClient().Patch(types.StrategicMergePatchType).
-		Namespace(obj.namespace).
-		Resource(lowercaseAndPluralise(obj.kind)).
-		Name(obj.name).
-		Body(patch)
+	owner, err := ownerClient.Get(ctx, ownerRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to get owner %s %s/%s: %w", ownerGVK.Kind, obj.GetNamespace(), ownerRef.Name, err)
+	}
 
-	_, err := req.Do(context.TODO()).Get()
+	if !hasPatchableTemplate(owner) {
+		klog.V(4).Infof("Owner %s %s/%s has no patch-compatible pod template, targeting %s %s/%s instead",
+			ownerGVK.Kind, obj.GetNamespace(), ownerRef.Name, gvk.Kind, obj.GetNamespace(), obj.GetName())
+		return &controllerObject{gvk: gvk, gvr: gvr, name: obj.GetName(), namespace: obj.GetNamespace()}, []ownerRefEntry{entry}, nil
+	}
+
+	cobj, chain, err := c.getOriginControllerObject(ctx, owner, ownerGVK, ownerGVR)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cobj, append([]ownerRefEntry{entry}, chain...), nil
+}
+
+func (c *ArchSchedulingController) patchToControllerObject(ctx context.Context, obj controllerObject, patch []byte) error {
+	resourceClient, _, err := c.resourceInterfaceFor(obj.gvk, obj.namespace)
 	if err != nil {
 		return err
 	}
 
+	_, err = resourceClient.Patch(ctx, obj.name, types.MergePatchType, patch, metav1.PatchOptions{})
+	if err != nil {
+		return xerrors.Errorf("failed to patch %s %s/%s: %w", obj.gvk.Kind, obj.namespace, obj.name, err)
+	}
+
 	return nil
 }
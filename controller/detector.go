@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+)
+
+// Detector inspects a pod for evidence that one of its containers failed
+// because its image was built for an architecture the node cannot execute.
+// handleObject tries the registered detectors in order and reschedules the
+// pod as soon as one of them reports a match, so new failure signatures
+// (e.g. a glibc symbol mismatch, or a SIGILL from a microarch mismatch) can
+// be added by registering another Detector rather than editing the existing
+// ones.
+type Detector interface {
+	// Name identifies the detector in logs and metrics.
+	Name() string
+	// Detect returns a non-nil DetectionResult when it finds evidence that
+	// pod needs rescheduling to another architecture.
+	Detect(ctx context.Context, pod *v1.Pod) (*DetectionResult, error)
+}
+
+// DetectionResult describes why a Detector believes a pod needs
+// rescheduling.
+type DetectionResult struct {
+	// Reason is a short machine-readable identifier, e.g. "exec-format-error".
+	Reason string
+	// Detail is a human-readable explanation, suitable for logs and events.
+	Detail string
+}
+
+type signature struct {
+	substring string
+	reason    string
+}
+
+// execFormatSignatures are substrings observed in termination messages,
+// events and logs produced by runc, crun, the containerd shim and CRI-O when
+// a container image targets an architecture the node cannot execute.
+var execFormatSignatures = []signature{
+	{`exec user process caused "exec format error"`, "exec-format-error"}, // runc <= 1.0, via standard_init_linux.go / process_linux.go
+	{"exec format error", "exec-format-error"},                            // crun, containerd-shim-runc-v2 and CRI-O all surface the bare errno string
+	{"no matching manifest for", "manifest-arch-mismatch"},                // containerd pulled a manifest list with no entry for the node's arch
+}
+
+// sigillExitCodes are shell exit-code conventions (128 + signal number) for
+// SIGILL (4), raised when a process hits an illegal instruction -- typically
+// because it was built for a newer microarchitecture than the CPU running it
+// implements.
+var sigillExitCodes = map[int32]bool{
+	132: true,
+}
+
+// relevantEventReasons are the v1.Event reasons runtimes use to surface a
+// container start failure before Kubernetes has even recorded a container
+// status for it.
+var relevantEventReasons = map[string]bool{
+	"Failed":                 true,
+	"FailedCreatePodSandBox": true,
+}
+
+func matchExecFormatMessage(message string) *DetectionResult {
+	lower := strings.ToLower(message)
+	for _, sig := range execFormatSignatures {
+		if strings.Contains(lower, strings.ToLower(sig.substring)) {
+			return &DetectionResult{Reason: sig.reason, Detail: message}
+		}
+	}
+	return nil
+}
+
+func matchExecFormatSignature(exitCode int32, reason, message string) *DetectionResult {
+	if res := matchExecFormatMessage(message); res != nil {
+		return res
+	}
+	if sigillExitCodes[exitCode] {
+		return &DetectionResult{
+			Reason: "sigill",
+			Detail: fmt.Sprintf("exit code %d (SIGILL), reason %q", exitCode, reason),
+		}
+	}
+	return nil
+}
+
+// terminationStateDetector inspects ContainerStatus.LastTerminationState for
+// the exit codes, reasons and messages runc, crun, the containerd shim and
+// CRI-O leave behind when a container fails for architecture reasons.
+type terminationStateDetector struct{}
+
+func (terminationStateDetector) Name() string { return "termination-state" }
+
+func (terminationStateDetector) Detect(_ context.Context, pod *v1.Pod) (*DetectionResult, error) {
+	for _, cs := range pod.Status.ContainerStatuses {
+		term := cs.LastTerminationState.Terminated
+		if term == nil {
+			continue
+		}
+		if res := matchExecFormatSignature(term.ExitCode, term.Reason, term.Message); res != nil {
+			res.Detail = fmt.Sprintf("container %q: %s", cs.Name, res.Detail)
+			return res, nil
+		}
+	}
+	return nil, nil
+}
+
+// eventDetector lists the Events correlated with a pod and looks for the
+// Failed / FailedCreatePodSandBox reasons runtimes report before a container
+// status has been recorded.
+type eventDetector struct {
+	kubeClientset kubernetes.Interface
+}
+
+func (eventDetector) Name() string { return "events" }
+
+func (d eventDetector) Detect(ctx context.Context, pod *v1.Pod) (*DetectionResult, error) {
+	events, err := d.kubeClientset.CoreV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("involvedObject.name=%s,involvedObject.namespace=%s,involvedObject.uid=%s", pod.Name, pod.Namespace, pod.UID),
+	})
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list events for pod %s/%s: %w", pod.Namespace, pod.Name, err)
+	}
+
+	for _, ev := range events.Items {
+		if !relevantEventReasons[ev.Reason] {
+			continue
+		}
+		if res := matchExecFormatMessage(ev.Message); res != nil {
+			res.Detail = fmt.Sprintf("event %s: %s", ev.Reason, res.Detail)
+			return res, nil
+		}
+	}
+	return nil, nil
+}
+
+// logRegexDetector is the fallback of last resort: it scrapes container logs
+// and matches them against a configurable regular expression. It exists to
+// cover runtimes or wrappers not yet known to matchExecFormatSignature, and
+// is expected to run after the structured detectors have had a chance to
+// match.
+type logRegexDetector struct {
+	kubeClientset  kubernetes.Interface
+	pattern        *regexp.Regexp
+	observeLatency func(seconds float64)
+}
+
+func newLogRegexDetector(kubeClientset kubernetes.Interface, pattern string, observeLatency func(seconds float64)) (*logRegexDetector, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, xerrors.Errorf("invalid log match pattern %q: %w", pattern, err)
+	}
+	if observeLatency == nil {
+		observeLatency = func(float64) {}
+	}
+	return &logRegexDetector{kubeClientset: kubeClientset, pattern: re, observeLatency: observeLatency}, nil
+}
+
+func (d *logRegexDetector) Name() string { return "log-regex" }
+
+func (d *logRegexDetector) Detect(ctx context.Context, pod *v1.Pod) (*DetectionResult, error) {
+	for _, ct := range pod.Spec.Containers {
+		start := time.Now()
+		req := d.kubeClientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: ct.Name})
+		body, err := req.Stream(ctx)
+		if err != nil {
+			klog.Errorf("Failed to get logs for %s/%s container %s: %s", pod.Namespace, pod.Name, ct.Name, err)
+			continue
+		}
+
+		var buf bytes.Buffer
+		_, copyErr := io.Copy(&buf, body)
+		closeErr := body.Close()
+		d.observeLatency(time.Since(start).Seconds())
+		if copyErr != nil {
+			klog.Errorf("Failed to read logs for %s/%s container %s: %s", pod.Namespace, pod.Name, ct.Name, copyErr)
+			continue
+		}
+		if closeErr != nil {
+			klog.Errorf("Failed to close log stream for %s/%s container %s: %s", pod.Namespace, pod.Name, ct.Name, closeErr)
+		}
+
+		if d.pattern.MatchString(buf.String()) {
+			return &DetectionResult{
+				Reason: "log-pattern-match",
+				Detail: fmt.Sprintf("container %q log matched pattern %q", ct.Name, d.pattern.String()),
+			}, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,94 @@
+package controller
+
+import "testing"
+
+func TestMatchExecFormatMessage(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		wantReason string
+		wantMatch  bool
+	}{
+		{
+			name:       "runc standard_init_linux message",
+			message:    `standard_init_linux.go:228: exec user process caused "exec format error"`,
+			wantReason: "exec-format-error",
+			wantMatch:  true,
+		},
+		{
+			name:       "crun bare errno string",
+			message:    "OCI runtime exec failed: exec format error: unknown",
+			wantReason: "exec-format-error",
+			wantMatch:  true,
+		},
+		{
+			name:       "containerd manifest list with no entry for node arch",
+			message:    `failed to pull image "example.com/app:v1": no matching manifest for linux/arm64 in the manifest list entries`,
+			wantReason: "manifest-arch-mismatch",
+			wantMatch:  true,
+		},
+		{
+			name:      "unrelated message",
+			message:   "container terminated with exit code 1",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := matchExecFormatMessage(tt.message)
+			if tt.wantMatch != (res != nil) {
+				t.Fatalf("matchExecFormatMessage(%q) = %v, want match=%v", tt.message, res, tt.wantMatch)
+			}
+			if tt.wantMatch && res.Reason != tt.wantReason {
+				t.Errorf("matchExecFormatMessage(%q).Reason = %q, want %q", tt.message, res.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestMatchExecFormatSignature(t *testing.T) {
+	tests := []struct {
+		name       string
+		exitCode   int32
+		reason     string
+		message    string
+		wantReason string
+		wantMatch  bool
+	}{
+		{
+			name:       "message takes precedence over exit code",
+			exitCode:   1,
+			message:    `exec user process caused "exec format error"`,
+			wantReason: "exec-format-error",
+			wantMatch:  true,
+		},
+		{
+			name:       "SIGILL exit code with no matching message",
+			exitCode:   132,
+			reason:     "Error",
+			message:    "",
+			wantReason: "sigill",
+			wantMatch:  true,
+		},
+		{
+			name:      "ordinary non-zero exit code",
+			exitCode:  1,
+			reason:    "Error",
+			message:   "panic: runtime error",
+			wantMatch: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := matchExecFormatSignature(tt.exitCode, tt.reason, tt.message)
+			if tt.wantMatch != (res != nil) {
+				t.Fatalf("matchExecFormatSignature(%d, %q, %q) = %v, want match=%v", tt.exitCode, tt.reason, tt.message, res, tt.wantMatch)
+			}
+			if tt.wantMatch && res.Reason != tt.wantReason {
+				t.Errorf("matchExecFormatSignature(%d, %q, %q).Reason = %q, want %q", tt.exitCode, tt.reason, tt.message, res.Reason, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1remote "github.com/google/go-containerregistry/pkg/v1/remote"
+	v1types "github.com/google/go-containerregistry/pkg/v1/types"
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// dockerConfigJSON is the subset of a kubernetes.io/dockerconfigjson
+// secret's .dockerconfigjson payload imageSupportedArches needs to recover
+// per-registry credentials.
+type dockerConfigJSON struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// podImagePullAuth resolves an authn.Authenticator for registry the same way
+// the kubelet does: from the image pull secrets attached to pod, falling
+// back to those attached to pod's service account.
+func (c *ArchSchedulingController) podImagePullAuth(ctx context.Context, pod *v1.Pod, registry string) (authn.Authenticator, error) {
+	secretNames := make([]string, 0, len(pod.Spec.ImagePullSecrets))
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		secretNames = append(secretNames, ref.Name)
+	}
+
+	if len(secretNames) == 0 {
+		saName := pod.Spec.ServiceAccountName
+		if saName == "" {
+			saName = "default"
+		}
+		sa, err := c.kubeClientset.CoreV1().ServiceAccounts(pod.Namespace).Get(ctx, saName, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return authn.Anonymous, nil
+			}
+			return nil, xerrors.Errorf("failed to get service account %q: %w", saName, err)
+		}
+		for _, ref := range sa.ImagePullSecrets {
+			secretNames = append(secretNames, ref.Name)
+		}
+	}
+
+	for _, name := range secretNames {
+		secret, err := c.kubeClientset.CoreV1().Secrets(pod.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, xerrors.Errorf("failed to get image pull secret %q: %w", name, err)
+		}
+
+		raw, ok := secret.Data[v1.DockerConfigJsonKey]
+		if !ok {
+			continue
+		}
+
+		var cfg dockerConfigJSON
+		if err := json.Unmarshal(raw, &cfg); err != nil {
+			continue
+		}
+
+		for _, key := range dockerConfigKeysForRegistry(registry) {
+			entry, ok := cfg.Auths[key]
+			if !ok {
+				continue
+			}
+			username, password := entry.Username, entry.Password
+			if username == "" && password == "" && entry.Auth != "" {
+				var decodeErr error
+				username, password, decodeErr = decodeDockerConfigAuth(entry.Auth)
+				if decodeErr != nil {
+					klog.Warningf("Failed to decode auth for registry %q in image pull secret %q: %s", registry, name, decodeErr)
+					continue
+				}
+			}
+			return &authn.Basic{Username: username, Password: password}, nil
+		}
+	}
+
+	return authn.Anonymous, nil
+}
+
+// dockerConfigKeysForRegistry returns the keys a .dockerconfigjson's "auths"
+// map may use for registry, in the order the kubelet's credential provider
+// tries them: Docker Hub images resolve to registry "index.docker.io" via
+// go-containerregistry, but docker login and Docker Hub itself key entries
+// by the legacy "https://index.docker.io/v1/" URL, and some other registries
+// are stored with a scheme or trailing "/v1/" as well.
+func dockerConfigKeysForRegistry(registry string) []string {
+	keys := []string{registry, "https://" + registry, "https://" + registry + "/v1/", registry + "/v1/"}
+	if registry == name.DefaultRegistry {
+		keys = append(keys, "https://index.docker.io/v1/", "index.docker.io")
+	}
+	return keys
+}
+
+// decodeDockerConfigAuth decodes a .dockerconfigjson auth entry's base64
+// "user:pass" blob, the form docker login and most CI systems and registry
+// token refreshers actually populate, as opposed to the separate
+// username/password fields.
+func decodeDockerConfigAuth(auth string) (username, password string, err error) {
+	decoded, err := base64.StdEncoding.DecodeString(auth)
+	if err != nil {
+		return "", "", xerrors.Errorf("failed to base64-decode auth: %w", err)
+	}
+	parts := strings.SplitN(string(decoded), ":", 2)
+	if len(parts) != 2 {
+		return "", "", xerrors.Errorf("auth does not contain a %q separator", ":")
+	}
+	return parts[0], parts[1], nil
+}
+
+// imageSupportedArches inspects image's manifest in its registry and, if it
+// resolves to a multi-architecture image (a Docker manifest list or OCI
+// image index), returns the set of architectures it supports. ok is false
+// when image resolves to a single-platform manifest, since that carries no
+// architecture information to intersect against - the caller should fall
+// back to whatever the cluster's node index found on its own.
+func (c *ArchSchedulingController) imageSupportedArches(ctx context.Context, pod *v1.Pod, image string) (arches map[string]bool, ok bool, err error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to parse image reference %q: %w", image, err)
+	}
+
+	auth, err := c.podImagePullAuth(ctx, pod, ref.Context().RegistryStr())
+	if err != nil {
+		return nil, false, err
+	}
+
+	desc, err := v1remote.Get(ref, v1remote.WithAuth(auth), v1remote.WithContext(ctx))
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to fetch manifest for %q: %w", image, err)
+	}
+
+	if desc.MediaType != v1types.OCIImageIndex && desc.MediaType != v1types.DockerManifestList {
+		return nil, false, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to read image index for %q: %w", image, err)
+	}
+
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, false, xerrors.Errorf("failed to read index manifest for %q: %w", image, err)
+	}
+
+	arches = make(map[string]bool)
+	for _, m := range manifest.Manifests {
+		if m.Platform != nil && m.Platform.Architecture != "" {
+			arches[m.Platform.Architecture] = true
+		}
+	}
+
+	return arches, true, nil
+}
+
+// intersectImageArches narrows candidates down to the architectures every
+// multi-arch container image in pod also supports. If none of pod's images
+// resolve to a multi-arch manifest, ok is false and arches is nil, since there
+// is nothing to intersect against; candidates itself is never modified.
+func (c *ArchSchedulingController) intersectImageArches(ctx context.Context, pod *v1.Pod, candidates map[string]bool) (arches map[string]bool, ok bool, err error) {
+	result := make(map[string]bool, len(candidates))
+	for a := range candidates {
+		result[a] = true
+	}
+
+	sawMultiArch := false
+	for _, container := range pod.Spec.Containers {
+		imageArches, isMultiArch, err := c.imageSupportedArches(ctx, pod, container.Image)
+		if err != nil {
+			return nil, false, xerrors.Errorf("failed to inspect manifest for image %q: %w", container.Image, err)
+		}
+		if !isMultiArch {
+			continue
+		}
+
+		sawMultiArch = true
+		for a := range result {
+			if !imageArches[a] {
+				delete(result, a)
+			}
+		}
+	}
+
+	if !sawMultiArch {
+		return nil, false, nil
+	}
+
+	return result, true, nil
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// compile-time assertion that Metrics implements workqueue.MetricsProvider.
+var _ workqueue.MetricsProvider = (*Metrics)(nil)
+
+// Metrics collects the Prometheus metrics ArchSchedulingController emits: how
+// often it detects an architecture mismatch and what it does about it, how
+// long the log-scrape fallback detector takes, and - via its
+// workqueue.MetricsProvider implementation - the reschedule workqueue's
+// depth and latency.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	detectionsTotal *prometheus.CounterVec
+	patchTotal      *prometheus.CounterVec
+	logFetchLatency prometheus.Histogram
+}
+
+// NewMetrics creates a Metrics collector backed by its own registry, so
+// importing this package never registers anything against prometheus'
+// global default registerer.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		detectionsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "arch_reschedule_detections_total",
+			Help: "Number of pods detected as having failed due to an architecture mismatch, by architecture and detection reason.",
+		}, []string{"arch", "reason"}),
+		patchTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "arch_reschedule_patch_total",
+			Help: "Number of attempts to patch an owning controller object's node affinity, by its kind and outcome.",
+		}, []string{"kind", "result"}),
+		logFetchLatency: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "arch_reschedule_log_fetch_duration_seconds",
+			Help:    "Time spent fetching container logs for the log-scrape detection fallback.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.detectionsTotal,
+		m.patchTotal,
+		m.logFetchLatency,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Handler serves m's metrics in the Prometheus exposition format, for
+// mounting at /metrics.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveDetection records a pod having been detected as arch-mismatched.
+func (m *Metrics) ObserveDetection(arch, reason string) {
+	m.detectionsTotal.WithLabelValues(arch, reason).Inc()
+}
+
+// ObservePatch records the outcome of a reschedule decision for an owning
+// controller object of the given kind.
+func (m *Metrics) ObservePatch(kind, result string) {
+	m.patchTotal.WithLabelValues(kind, result).Inc()
+}
+
+// ObserveLogFetchDuration records how long a log-scrape fallback fetch took.
+func (m *Metrics) ObserveLogFetchDuration(seconds float64) {
+	m.logFetchLatency.Observe(seconds)
+}
+
+// The methods below implement workqueue.MetricsProvider, so
+// workqueue.SetProvider(m) plugs the reschedule workqueue's depth, latency
+// and retry counts into the same registry as the rest of these metrics.
+
+func (m *Metrics) NewDepthMetric(name string) workqueue.GaugeMetric {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "arch_reschedule_workqueue_depth",
+		Help:        "Current depth of the reschedule workqueue.",
+		ConstLabels: prometheus.Labels{"name": name},
+	})
+	m.registry.MustRegister(g)
+	return g
+}
+
+func (m *Metrics) NewAddsMetric(name string) workqueue.CounterMetric {
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "arch_reschedule_workqueue_adds_total",
+		Help:        "Total number of items added to the reschedule workqueue.",
+		ConstLabels: prometheus.Labels{"name": name},
+	})
+	m.registry.MustRegister(c)
+	return c
+}
+
+func (m *Metrics) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:        "arch_reschedule_workqueue_queue_duration_seconds",
+		Help:        "How long an item stays in the reschedule workqueue before being processed.",
+		ConstLabels: prometheus.Labels{"name": name},
+		Buckets:     prometheus.DefBuckets,
+	})
+	m.registry.MustRegister(h)
+	return h
+}
+
+func (m *Metrics) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:        "arch_reschedule_workqueue_work_duration_seconds",
+		Help:        "How long processing an item off the reschedule workqueue takes.",
+		ConstLabels: prometheus.Labels{"name": name},
+		Buckets:     prometheus.DefBuckets,
+	})
+	m.registry.MustRegister(h)
+	return h
+}
+
+func (m *Metrics) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "arch_reschedule_workqueue_unfinished_work_seconds",
+		Help:        "Seconds of work that has been done by the reschedule workqueue's workers but not yet observed as finished.",
+		ConstLabels: prometheus.Labels{"name": name},
+	})
+	m.registry.MustRegister(g)
+	return g
+}
+
+func (m *Metrics) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	g := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "arch_reschedule_workqueue_longest_running_processor_seconds",
+		Help:        "How long the longest-running reschedule workqueue worker has been processing its current item.",
+		ConstLabels: prometheus.Labels{"name": name},
+	})
+	m.registry.MustRegister(g)
+	return g
+}
+
+func (m *Metrics) NewRetriesMetric(name string) workqueue.CounterMetric {
+	c := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:        "arch_reschedule_workqueue_retries_total",
+		Help:        "Total number of times an item was requeued into the reschedule workqueue for a retry.",
+		ConstLabels: prometheus.Labels{"name": name},
+	})
+	m.registry.MustRegister(c)
+	return c
+}
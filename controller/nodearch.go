@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	listers "k8s.io/client-go/listers/core/v1"
+
+	"golang.org/x/xerrors"
+)
+
+// NodeArchIndex answers, from the shared Node informer's cache, which
+// architectures are actually available to a pod with a given node selector
+// and set of tolerations. reSchedulePod consults it before patching a
+// workload's affinity towards an architecture so it never steers a workload
+// onto an architecture no schedulable node can satisfy.
+type NodeArchIndex struct {
+	nodeLister listers.NodeLister
+}
+
+// NewNodeArchIndex creates a NodeArchIndex backed by nodeLister.
+func NewNodeArchIndex(nodeLister listers.NodeLister) *NodeArchIndex {
+	return &NodeArchIndex{nodeLister: nodeLister}
+}
+
+// AvailableArches returns the set of architecture label values, read off
+// archLabelKey, present on nodes that match nodeSelector and tolerate their
+// own taints given tolerations. It reflects the shared informer cache, so it
+// may briefly lag the API server.
+func (idx *NodeArchIndex) AvailableArches(archLabelKey string, nodeSelector map[string]string, tolerations []v1.Toleration) (map[string]bool, error) {
+	nodes, err := idx.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list nodes: %w", err)
+	}
+
+	selector := labels.SelectorFromSet(nodeSelector)
+	arches := make(map[string]bool)
+	for _, node := range nodes {
+		if !selector.Matches(labels.Set(node.GetLabels())) {
+			continue
+		}
+		if !tolerationsToleratesTaints(tolerations, node.Spec.Taints) {
+			continue
+		}
+		if arch := node.GetLabels()[archLabelKey]; arch != "" {
+			arches[arch] = true
+		}
+	}
+
+	return arches, nil
+}
+
+// tolerationsToleratesTaints reports whether every taint in taints that
+// would otherwise make a node unschedulable (NoSchedule or NoExecute) is
+// tolerated by one of tolerations.
+func tolerationsToleratesTaints(tolerations []v1.Toleration, taints []v1.Taint) bool {
+	for i := range taints {
+		taint := &taints[i]
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+
+		tolerated := false
+		for _, t := range tolerations {
+			if t.ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// pickArch deterministically picks one architecture out of arches, so two
+// runs presented with the same candidate set make the same choice.
+func pickArch(arches map[string]bool) (string, bool) {
+	if len(arches) == 0 {
+		return "", false
+	}
+
+	values := make([]string, 0, len(arches))
+	for a := range arches {
+		values = append(values, a)
+	}
+	sort.Strings(values)
+
+	return values[0], true
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestTolerationsToleratesTaints(t *testing.T) {
+	noScheduleTaint := v1.Taint{Key: "arch", Value: "arm64", Effect: v1.TaintEffectNoSchedule}
+	noExecuteTaint := v1.Taint{Key: "arch", Value: "arm64", Effect: v1.TaintEffectNoExecute}
+	preferNoScheduleTaint := v1.Taint{Key: "arch", Value: "arm64", Effect: v1.TaintEffectPreferNoSchedule}
+	matchingToleration := v1.Toleration{Key: "arch", Operator: v1.TolerationOpEqual, Value: "arm64", Effect: v1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name        string
+		tolerations []v1.Toleration
+		taints      []v1.Taint
+		want        bool
+	}{
+		{name: "no taints", taints: nil, want: true},
+		{
+			name:        "PreferNoSchedule taint is not blocking even without a toleration",
+			taints:      []v1.Taint{preferNoScheduleTaint},
+			tolerations: nil,
+			want:        true,
+		},
+		{
+			name:        "NoSchedule taint without a matching toleration",
+			taints:      []v1.Taint{noScheduleTaint},
+			tolerations: nil,
+			want:        false,
+		},
+		{
+			name:        "NoSchedule taint with a matching toleration",
+			taints:      []v1.Taint{noScheduleTaint},
+			tolerations: []v1.Toleration{matchingToleration},
+			want:        true,
+		},
+		{
+			name:        "NoExecute taint requires its own toleration",
+			taints:      []v1.Taint{noExecuteTaint},
+			tolerations: []v1.Toleration{matchingToleration},
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tolerationsToleratesTaints(tt.tolerations, tt.taints); got != tt.want {
+				t.Errorf("tolerationsToleratesTaints() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickArch(t *testing.T) {
+	if _, ok := pickArch(map[string]bool{}); ok {
+		t.Error("pickArch(empty) returned ok=true, want false")
+	}
+
+	arch, ok := pickArch(map[string]bool{"arm64": true, "amd64": true})
+	if !ok || arch != "amd64" {
+		t.Errorf("pickArch({arm64,amd64}) = (%q, %v), want (\"amd64\", true)", arch, ok)
+	}
+
+	// Calling it again with the same set must yield the same architecture.
+	arch2, _ := pickArch(map[string]bool{"arm64": true, "amd64": true})
+	if arch2 != arch {
+		t.Errorf("pickArch() is not deterministic: got %q then %q", arch, arch2)
+	}
+}
+
+func newTestNode(name, arch string, labels map[string]string, taints []v1.Taint) *v1.Node {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels["kubernetes.io/arch"] = arch
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       v1.NodeSpec{Taints: taints},
+	}
+}
+
+func TestNodeArchIndexAvailableArches(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	nodes := []*v1.Node{
+		newTestNode("amd64-node", "amd64", nil, nil),
+		newTestNode("arm64-node", "arm64", nil, nil),
+		newTestNode("tainted-arm64-node", "arm64", nil, []v1.Taint{
+			{Key: "dedicated", Value: "arm64", Effect: v1.TaintEffectNoSchedule},
+		}),
+		newTestNode("pool-b-s390x-node", "s390x", map[string]string{"pool": "b"}, nil),
+	}
+	for _, node := range nodes {
+		if err := indexer.Add(node); err != nil {
+			t.Fatalf("failed to add node %q to indexer: %s", node.Name, err)
+		}
+	}
+
+	idx := NewNodeArchIndex(listers.NewNodeLister(indexer))
+
+	arches, err := idx.AvailableArches("kubernetes.io/arch", nil, nil)
+	if err != nil {
+		t.Fatalf("AvailableArches returned error: %s", err)
+	}
+	want := map[string]bool{"amd64": true, "arm64": true, "s390x": true}
+	if len(arches) != len(want) {
+		t.Fatalf("AvailableArches() = %v, want %v (a nil selector matches every node; only the tainted node is excluded)", arches, want)
+	}
+	for arch := range want {
+		if !arches[arch] {
+			t.Errorf("AvailableArches() missing expected architecture %q: %v", arch, arches)
+		}
+	}
+
+	toleratingArches, err := idx.AvailableArches("kubernetes.io/arch", nil, []v1.Toleration{
+		{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "arm64", Effect: v1.TaintEffectNoSchedule},
+	})
+	if err != nil {
+		t.Fatalf("AvailableArches returned error: %s", err)
+	}
+	if !toleratingArches["arm64"] {
+		t.Errorf("AvailableArches() with a matching toleration excluded the tainted node: %v", toleratingArches)
+	}
+
+	selectedArches, err := idx.AvailableArches("kubernetes.io/arch", map[string]string{"pool": "b"}, nil)
+	if err != nil {
+		t.Fatalf("AvailableArches returned error: %s", err)
+	}
+	if len(selectedArches) != 1 || !selectedArches["s390x"] {
+		t.Errorf("AvailableArches() with nodeSelector {pool: b} = %v, want {s390x: true}", selectedArches)
+	}
+}
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/xerrors"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	schedulingv1alpha1 "github.com/hnts/arch-scheduling-controller/pkg/apis/scheduling/v1alpha1"
+)
+
+// defaultArchLabelKey is used whenever a policy (or the fallback below)
+// leaves ArchLabelKey unset.
+const defaultArchLabelKey = "kubernetes.io/arch"
+
+// defaultPolicySpec is applied to a pod when no ArchSchedulingPolicy selects
+// it. It writes the same required NotIn affinity the controller used to
+// hard-code, but on the "kubernetes.io/arch" label rather than the
+// deprecated "beta.kubernetes.io/arch" one.
+var defaultPolicySpec = schedulingv1alpha1.ArchSchedulingPolicySpec{
+	ArchLabelKey:   defaultArchLabelKey,
+	Strategy:       schedulingv1alpha1.StrategyExcludeFailedArch,
+	SchedulingTerm: schedulingv1alpha1.SchedulingTermRequired,
+}
+
+// resolvePolicySpec fills in the zero-valued fields of spec with their
+// defaults, since CRs are allowed to omit them.
+func resolvePolicySpec(spec schedulingv1alpha1.ArchSchedulingPolicySpec) schedulingv1alpha1.ArchSchedulingPolicySpec {
+	if spec.ArchLabelKey == "" {
+		spec.ArchLabelKey = defaultArchLabelKey
+	}
+	if spec.Strategy == "" {
+		spec.Strategy = schedulingv1alpha1.StrategyExcludeFailedArch
+	}
+	if spec.SchedulingTerm == "" {
+		spec.SchedulingTerm = schedulingv1alpha1.SchedulingTermRequired
+	}
+	return spec
+}
+
+// selectPolicy returns the ArchSchedulingPolicy that applies to pod, or nil
+// if none match. When more than one policy matches, the one whose name
+// sorts first wins; operators are expected to keep selectors disjoint.
+func (c *ArchSchedulingController) selectPolicy(pod *v1.Pod) (*schedulingv1alpha1.ArchSchedulingPolicy, error) {
+	policies, err := c.policyLister.List(labels.Everything())
+	if err != nil {
+		return nil, xerrors.Errorf("failed to list ArchSchedulingPolicy objects: %w", err)
+	}
+
+	var namespaceLabels map[string]string
+	var matched *schedulingv1alpha1.ArchSchedulingPolicy
+	for _, p := range policies {
+		if p.Spec.NamespaceSelector != nil {
+			if namespaceLabels == nil {
+				ns, err := c.kubeClientset.CoreV1().Namespaces().Get(context.TODO(), pod.Namespace, metav1.GetOptions{})
+				if err != nil {
+					return nil, xerrors.Errorf("failed to get namespace %q: %w", pod.Namespace, err)
+				}
+				namespaceLabels = ns.GetLabels()
+			}
+			sel, err := metav1.LabelSelectorAsSelector(p.Spec.NamespaceSelector)
+			if err != nil {
+				return nil, xerrors.Errorf("policy %q has an invalid namespaceSelector: %w", p.Name, err)
+			}
+			if !sel.Matches(labels.Set(namespaceLabels)) {
+				continue
+			}
+		}
+
+		if p.Spec.Selector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+			if err != nil {
+				return nil, xerrors.Errorf("policy %q has an invalid selector: %w", p.Name, err)
+			}
+			if !sel.Matches(labels.Set(pod.Labels)) {
+				continue
+			}
+		}
+
+		if matched == nil || p.Name < matched.Name {
+			matched = p
+		}
+	}
+
+	return matched, nil
+}
+
+// affinityPatch is the JSON merge patch body buildAffinityPatch produces; it
+// only ever touches the pod template's node affinity.
+type affinityPatch struct {
+	Spec struct {
+		Template struct {
+			Spec struct {
+				Affinity *v1.Affinity `json:"affinity"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+}
+
+// buildAffinityPatch renders the node affinity spec dictates into the JSON
+// merge patch body applied to the owning controller object's pod template.
+func buildAffinityPatch(spec schedulingv1alpha1.ArchSchedulingPolicySpec, operator v1.NodeSelectorOperator, archValue string) ([]byte, error) {
+	term := v1.NodeSelectorTerm{
+		MatchExpressions: []v1.NodeSelectorRequirement{
+			{
+				Key:      spec.ArchLabelKey,
+				Operator: operator,
+				Values:   []string{archValue},
+			},
+		},
+	}
+
+	affinity := &v1.Affinity{NodeAffinity: &v1.NodeAffinity{}}
+	if spec.SchedulingTerm == schedulingv1alpha1.SchedulingTermPreferred {
+		affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []v1.PreferredSchedulingTerm{
+			{Weight: 100, Preference: term},
+		}
+	} else {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{term},
+		}
+	}
+
+	var patch affinityPatch
+	patch.Spec.Template.Spec.Affinity = affinity
+
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to marshal affinity patch: %w", err)
+	}
+
+	return body, nil
+}
+
+// patchCooldown tracks, per owning controller object, the last time it was
+// patched, so a policy's Cooldown can be enforced even though reSchedulePod
+// runs once per failing pod rather than once per controller object.
+type patchCooldown struct {
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newPatchCooldown() *patchCooldown {
+	return &patchCooldown{last: make(map[string]time.Time)}
+}
+
+func cooldownKey(obj controllerObject) string {
+	return fmt.Sprintf("%s/%s/%s", obj.gvk.Kind, obj.namespace, obj.name)
+}
+
+// elapsed reports whether cooldown has passed since the last recorded patch
+// of obj. A non-positive cooldown always allows the patch.
+func (p *patchCooldown) elapsed(obj controllerObject, cooldown time.Duration) bool {
+	if cooldown <= 0 {
+		return true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	last, ok := p.last[cooldownKey(obj)]
+	return !ok || time.Since(last) >= cooldown
+}
+
+func (p *patchCooldown) record(obj controllerObject) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.last[cooldownKey(obj)] = time.Now()
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	schedulingv1alpha1 "github.com/hnts/arch-scheduling-controller/pkg/apis/scheduling/v1alpha1"
+)
+
+func TestResolvePolicySpec(t *testing.T) {
+	resolved := resolvePolicySpec(schedulingv1alpha1.ArchSchedulingPolicySpec{})
+	if resolved.ArchLabelKey != defaultArchLabelKey {
+		t.Errorf("ArchLabelKey = %q, want %q", resolved.ArchLabelKey, defaultArchLabelKey)
+	}
+	if resolved.Strategy != schedulingv1alpha1.StrategyExcludeFailedArch {
+		t.Errorf("Strategy = %q, want %q", resolved.Strategy, schedulingv1alpha1.StrategyExcludeFailedArch)
+	}
+	if resolved.SchedulingTerm != schedulingv1alpha1.SchedulingTermRequired {
+		t.Errorf("SchedulingTerm = %q, want %q", resolved.SchedulingTerm, schedulingv1alpha1.SchedulingTermRequired)
+	}
+
+	explicit := schedulingv1alpha1.ArchSchedulingPolicySpec{
+		ArchLabelKey:   "custom/arch",
+		Strategy:       schedulingv1alpha1.StrategyPreferDiscoveredArch,
+		SchedulingTerm: schedulingv1alpha1.SchedulingTermPreferred,
+	}
+	if resolvePolicySpec(explicit) != explicit {
+		t.Errorf("resolvePolicySpec changed an already-populated spec: got %+v, want %+v", resolvePolicySpec(explicit), explicit)
+	}
+}
+
+func TestBuildAffinityPatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		schedulingTerm schedulingv1alpha1.SchedulingTermType
+	}{
+		{name: "required term", schedulingTerm: schedulingv1alpha1.SchedulingTermRequired},
+		{name: "preferred term", schedulingTerm: schedulingv1alpha1.SchedulingTermPreferred},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := schedulingv1alpha1.ArchSchedulingPolicySpec{
+				ArchLabelKey:   "kubernetes.io/arch",
+				SchedulingTerm: tt.schedulingTerm,
+			}
+
+			body, err := buildAffinityPatch(spec, v1.NodeSelectorOpIn, "arm64")
+			if err != nil {
+				t.Fatalf("buildAffinityPatch returned error: %s", err)
+			}
+
+			var patch affinityPatch
+			if err := json.Unmarshal(body, &patch); err != nil {
+				t.Fatalf("patch body is not valid JSON: %s", err)
+			}
+			affinity := patch.Spec.Template.Spec.Affinity
+			if affinity == nil || affinity.NodeAffinity == nil {
+				t.Fatalf("patch has no node affinity: %+v", patch)
+			}
+
+			switch tt.schedulingTerm {
+			case schedulingv1alpha1.SchedulingTermPreferred:
+				terms := affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+				if len(terms) != 1 {
+					t.Fatalf("PreferredDuringSchedulingIgnoredDuringExecution has %d terms, want 1", len(terms))
+				}
+				if affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+					t.Errorf("preferred term also set RequiredDuringSchedulingIgnoredDuringExecution")
+				}
+				expr := terms[0].Preference.MatchExpressions[0]
+				if expr.Key != "kubernetes.io/arch" || expr.Operator != v1.NodeSelectorOpIn || expr.Values[0] != "arm64" {
+					t.Errorf("unexpected match expression: %+v", expr)
+				}
+			default:
+				required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+				if required == nil || len(required.NodeSelectorTerms) != 1 {
+					t.Fatalf("RequiredDuringSchedulingIgnoredDuringExecution missing or malformed: %+v", required)
+				}
+				if len(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution) != 0 {
+					t.Errorf("required term also set PreferredDuringSchedulingIgnoredDuringExecution")
+				}
+				expr := required.NodeSelectorTerms[0].MatchExpressions[0]
+				if expr.Key != "kubernetes.io/arch" || expr.Operator != v1.NodeSelectorOpIn || expr.Values[0] != "arm64" {
+					t.Errorf("unexpected match expression: %+v", expr)
+				}
+			}
+		})
+	}
+}
+
+func TestPatchCooldownElapsed(t *testing.T) {
+	obj := controllerObject{gvk: schema.GroupVersionKind{Kind: "Deployment"}, namespace: "default", name: "web"}
+
+	cooldown := newPatchCooldown()
+	if !cooldown.elapsed(obj, time.Hour) {
+		t.Fatal("elapsed() = false before any patch was recorded, want true")
+	}
+
+	cooldown.record(obj)
+	if cooldown.elapsed(obj, time.Hour) {
+		t.Fatal("elapsed() = true immediately after recording a patch, want false")
+	}
+
+	if !cooldown.elapsed(obj, 0) {
+		t.Fatal("elapsed() = false with a non-positive cooldown, want true")
+	}
+
+	other := controllerObject{gvk: schema.GroupVersionKind{Kind: "Deployment"}, namespace: "default", name: "other"}
+	if !cooldown.elapsed(other, time.Hour) {
+		t.Fatal("elapsed() = false for a different controller object, want true")
+	}
+}
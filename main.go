@@ -1,16 +1,31 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"net/http"
+	"os"
 	"path/filepath"
 	"time"
 
+	"golang.org/x/xerrors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+	cacheddiscovery "k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/util/homedir"
 	"k8s.io/component-base/logs"
 	"k8s.io/klog"
+
+	"github.com/hnts/arch-scheduling-controller/controller"
+	policyclientset "github.com/hnts/arch-scheduling-controller/pkg/generated/clientset/versioned"
+	policyinformers "github.com/hnts/arch-scheduling-controller/pkg/generated/informers/externalversions"
 )
 
 func main() {
@@ -26,6 +41,18 @@ func main() {
 		kubeconfig = flag.String("kubeconfig", "", "kubeconfig file")
 	}
 
+	logMatchPattern := flag.String("log-match-pattern", `exec user process caused "exec format error"`,
+		"regular expression used as a fallback to match exec-format failures in container logs when no structured signal (termination state, events) is found")
+	concurrentWorkers := flag.Int("concurrent-workers", 2, "number of workers processing the reschedule workqueue concurrently")
+	leaderElect := flag.Bool("leader-elect", true, "run leader election so only one replica patches workloads at a time")
+	leaderElectionNamespace := flag.String("leader-election-namespace", "default", "namespace of the Lease object used for leader election")
+	leaderElectionLeaseName := flag.String("leader-election-lease-name", "arch-scheduling-controller", "name of the Lease object used for leader election")
+	healthAddr := flag.String("health-addr", ":8080", "address the /healthz and /readyz endpoints are served on")
+	inspectImageManifests := flag.Bool("inspect-image-manifests", false,
+		"before patching, fetch each container's OCI manifest and narrow rescheduling to architectures the image itself supports, not just the ones present in the cluster")
+	auditLogPath := flag.String("audit-log-path", "",
+		`path to append a JSON record of every rescheduling decision to; "-" writes to stdout, and an empty value (the default) disables the audit sink`)
+
 	flag.Parse()
 	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
 	if err != nil {
@@ -37,14 +64,134 @@ func main() {
 		klog.Fatal(err)
 	}
 
+	policyClientset, err := policyclientset.NewForConfig(config)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		klog.Fatal(err)
+	}
+	restMapper := restmapper.NewDeferredDiscoveryRESTMapper(cacheddiscovery.NewMemCacheClient(discoveryClient))
+
+	auditSink, err := newAuditSink(*auditLogPath)
+	if err != nil {
+		klog.Fatal(err)
+	}
+
 	factory := informers.NewSharedInformerFactory(clientset, time.Second*30)
-	controller := NewArchSchedulingController(factory, clientset)
-	stop := make(chan struct{})
-	defer close(stop)
+	policyFactory := policyinformers.NewSharedInformerFactory(policyClientset, time.Second*30)
+	metrics := controller.NewMetrics()
+	c, err := controller.NewArchSchedulingController(factory, policyFactory, clientset, dynamicClient, restMapper, *logMatchPattern, *inspectImageManifests, metrics, auditSink)
+	if err != nil {
+		klog.Fatal(err)
+	}
 
-	err = controller.Run(stop)
+	leaderHealth := leaderelection.NewLeaderHealthzAdaptor(20 * time.Second)
+	go serveHealth(*healthAddr, c, leaderHealth)
+
+	run := func(ctx context.Context) {
+		stopCh := make(chan struct{})
+		go func() {
+			<-ctx.Done()
+			close(stopCh)
+		}()
+		if err := c.Run(stopCh, *concurrentWorkers); err != nil {
+			klog.Fatal(err)
+		}
+	}
+
+	if !*leaderElect {
+		run(context.Background())
+		return
+	}
+
+	id, err := os.Hostname()
 	if err != nil {
 		klog.Fatal(err)
 	}
-	select {}
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      *leaderElectionLeaseName,
+			Namespace: *leaderElectionNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	}
+
+	leaderelection.RunOrDie(context.Background(), leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   15 * time.Second,
+		RenewDeadline:   10 * time.Second,
+		RetryPeriod:     2 * time.Second,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: run,
+			OnStoppedLeading: func() {
+				klog.Infof("%s: leader election lost", id)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != id {
+					klog.Infof("New leader elected: %s", identity)
+				}
+			},
+		},
+		WatchDog: leaderHealth,
+		Name:     *leaderElectionLeaseName,
+	})
+}
+
+// serveHealth exposes the liveness, readiness and metrics endpoints a
+// Deployment-managed replica needs: /healthz reports whether the leader
+// election loop is still renewing on time, /readyz reports whether the
+// controller's informer caches have completed their initial sync, and
+// /metrics serves c's Prometheus metrics.
+func serveHealth(addr string, c *controller.ArchSchedulingController, leaderHealth *leaderelection.HealthzAdaptor) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if err := leaderHealth.Check(r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if !c.HasSynced() {
+			http.Error(w, "informer caches not yet synced", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/metrics", c.Metrics().Handler())
+
+	klog.Infof("Serving health checks and metrics on %s", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		klog.Errorf("Health check server exited: %s", err)
+	}
+}
+
+// newAuditSink builds the AuditSink path configures: nil when path is empty,
+// stdout when path is "-", or an append-mode file at path otherwise.
+func newAuditSink(path string) (controller.AuditSink, error) {
+	switch path {
+	case "":
+		return nil, nil
+	case "-":
+		return controller.NewJSONAuditSink(os.Stdout), nil
+	default:
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to open audit log %q: %w", path, err)
+		}
+		return controller.NewJSONAuditSink(f), nil
+	}
 }
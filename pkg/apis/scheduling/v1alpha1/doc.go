@@ -0,0 +1,7 @@
+// +k8s:deepcopy-gen=package
+// +groupName=scheduling.hnts.io
+
+// Package v1alpha1 is the v1alpha1 version of the scheduling.hnts.io API
+// group, which carries the ArchSchedulingPolicy custom resource consumed by
+// ArchSchedulingController.
+package v1alpha1
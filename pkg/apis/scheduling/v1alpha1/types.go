@@ -0,0 +1,103 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ArchSchedulingStrategy selects how the affinity written by the controller
+// steers a rescheduled workload away from an architecture it cannot run on.
+type ArchSchedulingStrategy string
+
+const (
+	// StrategyExcludeFailedArch writes a NotIn match on the architecture
+	// the pod failed on. This is the controller's original behaviour.
+	StrategyExcludeFailedArch ArchSchedulingStrategy = "ExcludeFailedArch"
+	// StrategyPreferDiscoveredArch writes an In match on an architecture
+	// the controller has discovered to be available elsewhere in the
+	// cluster.
+	StrategyPreferDiscoveredArch ArchSchedulingStrategy = "PreferDiscoveredArch"
+)
+
+// SchedulingTermType selects whether the node affinity term the controller
+// writes is a hard requirement or a soft preference.
+type SchedulingTermType string
+
+const (
+	// SchedulingTermRequired writes the affinity under
+	// requiredDuringSchedulingIgnoredDuringExecution.
+	SchedulingTermRequired SchedulingTermType = "RequiredDuringScheduling"
+	// SchedulingTermPreferred writes the affinity under
+	// preferredDuringSchedulingIgnoredDuringExecution.
+	SchedulingTermPreferred SchedulingTermType = "PreferredDuringScheduling"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArchSchedulingPolicy configures how ArchSchedulingController reschedules
+// workloads that failed due to an architecture mismatch. It is cluster
+// scoped so a single policy can govern workloads across namespaces; use
+// Spec.NamespaceSelector and Spec.Selector to narrow its reach.
+type ArchSchedulingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ArchSchedulingPolicySpec   `json:"spec"`
+	Status ArchSchedulingPolicyStatus `json:"status,omitempty"`
+}
+
+// ArchSchedulingPolicySpec is the desired behaviour for workloads matched by
+// this policy.
+type ArchSchedulingPolicySpec struct {
+	// ArchLabelKey is the node label used to express the architecture
+	// constraint, e.g. "kubernetes.io/arch" or the deprecated
+	// "beta.kubernetes.io/arch". Defaults to "kubernetes.io/arch".
+	// +optional
+	ArchLabelKey string `json:"archLabelKey,omitempty"`
+
+	// Strategy selects whether the written affinity excludes the failed
+	// architecture or prefers a discovered good one. Defaults to
+	// ExcludeFailedArch.
+	// +optional
+	Strategy ArchSchedulingStrategy `json:"strategy,omitempty"`
+
+	// SchedulingTerm selects whether the affinity is a hard requirement or
+	// a soft preference. Defaults to RequiredDuringScheduling.
+	// +optional
+	SchedulingTerm SchedulingTermType `json:"schedulingTerm,omitempty"`
+
+	// Selector restricts which workloads this policy applies to. A nil
+	// selector matches every workload in the matched namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// NamespaceSelector restricts which namespaces this policy applies to.
+	// A nil selector matches every namespace.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Cooldown is the minimum duration the controller waits before patching
+	// the same owning controller object again.
+	// +optional
+	Cooldown metav1.Duration `json:"cooldown,omitempty"`
+}
+
+// ArchSchedulingPolicyStatus holds the observed state of this policy.
+// ArchSchedulingController reads policies but never writes their status.
+type ArchSchedulingPolicyStatus struct {
+	// ObservedGeneration is the most recent Spec generation acted on. The
+	// controller does not set it.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ArchSchedulingPolicyList is a list of ArchSchedulingPolicy resources.
+type ArchSchedulingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ArchSchedulingPolicy `json:"items"`
+}